@@ -0,0 +1,242 @@
+package frontend
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+// profileProgressFactory is used to provide a way to construct a shardedProfileProgress. It mirrors
+// searchProgressFactory so that profile search can be sharded across queriers the same way trace search is.
+// shardProfileSearch is its caller today; a profile search HTTP handler would hold one of these and pass it
+// through the same way a trace search handler holds a searchProgressFactory.
+type profileProgressFactory func(ctx context.Context, limit, totalJobs, totalBlocks, totalBlockBytes int) shardedProfileProgress
+
+// shardedProfileProgress is the profile search sibling of shardedSearchProgress. It allows us to get progress
+// events from the profile search sharding handler.
+type shardedProfileProgress interface {
+	setStatus(statusCode int, statusMsg string)
+	setError(err error)
+	addResponse(res *profileSearchResponse)
+	shouldQuit() bool
+	result() *shardedProfileResults
+}
+
+// profileSearchResponse is a single querier's partial profile search result. Unlike tempopb.SearchResponse,
+// this isn't a gogo-proto message: pprofile.ScopeProfilesSlice is itself OTel's wire-compatible
+// representation of profile data, and a gogo message can't hold it as a field, so there's no tempopb type
+// to mirror tempopb.SearchResponse with. Queriers return this shape over whatever transport the profile
+// search RPC ends up using (e.g. marshaled via pprofile's own protobuf encoding), and the frontend
+// aggregates it here the same way it aggregates tempopb.SearchResponse in searchProgress.
+type profileSearchResponse struct {
+	Profiles pprofile.ScopeProfilesSlice
+	Metrics  *profileSearchMetrics
+}
+
+// profileSearchMetrics is the profile search sibling of tempopb.SearchMetrics. It is deliberately an
+// internal struct rather than a generated tempopb.ProfileSearchMetrics message: the frontend/querier RPC
+// this would travel over doesn't exist in this tree yet, so there's nothing for a proto message to be
+// wire-compatible with today. When that RPC is added, this should become an actual tempopb message
+// (defined alongside SearchMetrics in tempopb.proto) instead of this struct, the same way
+// profileSearchResponse's Profiles field should eventually ride whatever protobuf pprofile itself
+// generates; shipping the real proto type now, with no producer/consumer to exercise it, isn't done
+// here.
+type profileSearchMetrics struct {
+	InspectedProfiles uint64
+	InspectedBytes    uint64
+	TotalBlocks       uint32
+	CompletedJobs     uint32
+	TotalJobs         uint32
+	TotalBlockBytes   uint64
+}
+
+// shardedProfileResults is the overall response from the shardedProfileProgress
+type shardedProfileResults struct {
+	response         *profileSearchResponse
+	statusCode       int
+	statusMsg        string
+	err              error
+	finishedRequests int
+}
+
+var _ shardedProfileProgress = (*profileProgress)(nil)
+
+// profileProgress is a thread safe struct used to aggregate the profile responses from all downstream
+// queriers
+type profileProgress struct {
+	err        error
+	statusCode int
+	statusMsg  string
+	ctx        context.Context
+
+	resultsCombiner  *profileCombiner
+	resultsMetrics   *profileSearchMetrics
+	finishedRequests int
+
+	limit int
+	mtx   sync.Mutex
+}
+
+func newProfileProgress(ctx context.Context, limit, totalJobs, totalBlocks, totalBlockBytes int) shardedProfileProgress {
+	return &profileProgress{
+		ctx:              ctx,
+		statusCode:       http.StatusOK,
+		limit:            limit,
+		finishedRequests: 0,
+		resultsMetrics: &profileSearchMetrics{
+			TotalBlocks:     uint32(totalBlocks),
+			TotalBlockBytes: uint64(totalBlockBytes),
+			TotalJobs:       uint32(totalJobs),
+		},
+		resultsCombiner: newProfileCombiner(),
+	}
+}
+
+func (r *profileProgress) setStatus(statusCode int, statusMsg string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.statusCode = statusCode
+	r.statusMsg = statusMsg
+}
+
+func (r *profileProgress) setError(err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.err = err
+}
+
+func (r *profileProgress) addResponse(res *profileSearchResponse) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.resultsCombiner.AddProfiles(res.Profiles)
+
+	// purposefully ignoring TotalBlocks as that value is set by the sharder
+	r.resultsMetrics.InspectedBytes += res.Metrics.InspectedBytes
+	r.resultsMetrics.InspectedProfiles += res.Metrics.InspectedProfiles
+	r.resultsMetrics.CompletedJobs++
+
+	// count this request as finished
+	r.finishedRequests++
+}
+
+// shouldQuit locks and checks if we should quit from current execution or not
+func (r *profileProgress) shouldQuit() bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return r.internalShouldQuit()
+}
+
+// internalShouldQuit check if we should quit but without locking,
+// NOTE: only use internally where we already hold lock on profileProgress
+func (r *profileProgress) internalShouldQuit() bool {
+	if r.err != nil {
+		return true
+	}
+	if r.ctx.Err() != nil {
+		return true
+	}
+	if r.statusCode/100 != 2 {
+		return true
+	}
+	if r.resultsCombiner.Count() > r.limit {
+		return true
+	}
+
+	return false
+}
+
+func (r *profileProgress) result() *shardedProfileResults {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	res := &shardedProfileResults{
+		statusCode:       r.statusCode,
+		statusMsg:        r.statusMsg,
+		err:              r.err,
+		finishedRequests: r.finishedRequests,
+	}
+
+	// bound memory now that we know we have everything we're going to combine: drop any scopes beyond
+	// what the caller asked for instead of carrying them through the response.
+	r.resultsCombiner.TrimTo(r.limit)
+
+	res.response = &profileSearchResponse{
+		// clone profile metrics to avoid race conditions on the pointer
+		Metrics: &profileSearchMetrics{
+			InspectedProfiles: r.resultsMetrics.InspectedProfiles,
+			InspectedBytes:    r.resultsMetrics.InspectedBytes,
+			TotalBlocks:       r.resultsMetrics.TotalBlocks,
+			CompletedJobs:     r.resultsMetrics.CompletedJobs,
+			TotalJobs:         r.resultsMetrics.TotalJobs,
+			TotalBlockBytes:   r.resultsMetrics.TotalBlockBytes,
+		},
+		Profiles: r.resultsCombiner.Profiles(),
+	}
+
+	return res
+}
+
+// profileCombiner merges per-shard pprofile.ScopeProfilesSlice results the same way traceql.MetadataCombiner
+// merges trace metadata. It keeps everything added to it; TrimTo bounds it to a number of individual
+// profiles, counted across scopes.
+type profileCombiner struct {
+	profiles pprofile.ScopeProfilesSlice
+}
+
+func newProfileCombiner() *profileCombiner {
+	return &profileCombiner{profiles: pprofile.NewScopeProfilesSlice()}
+}
+
+// AddProfiles moves the given slice's contents into the combiner. The source slice is left empty, matching
+// the semantics of MoveAndAppendTo.
+func (c *profileCombiner) AddProfiles(incoming pprofile.ScopeProfilesSlice) {
+	incoming.MoveAndAppendTo(c.profiles)
+}
+
+// TrimTo drops individual profiles beyond the given limit, counted across all scopes rather than
+// per-scope, since a single ScopeProfiles can hold many profiles. Scopes left with no profiles after
+// trimming are dropped entirely. Used by callers that want to bound memory once they know they already
+// have enough results.
+func (c *profileCombiner) TrimTo(limit int) {
+	if limit <= 0 {
+		return
+	}
+
+	kept := 0
+	c.profiles.RemoveIf(func(sp pprofile.ScopeProfiles) bool {
+		if kept >= limit {
+			return true
+		}
+
+		profiles := sp.Profiles()
+		profiles.RemoveIf(func(pprofile.Profile) bool {
+			if kept >= limit {
+				return true
+			}
+			kept++
+			return false
+		})
+
+		return profiles.Len() == 0
+	})
+}
+
+// Count returns the total number of individual profiles held across all scopes, not the number of
+// ScopeProfiles entries, since one ScopeProfiles can hold many profiles.
+func (c *profileCombiner) Count() int {
+	count := 0
+	for i := 0; i < c.profiles.Len(); i++ {
+		count += c.profiles.At(i).Profiles().Len()
+	}
+	return count
+}
+
+func (c *profileCombiner) Profiles() pprofile.ScopeProfilesSlice {
+	return c.profiles
+}
@@ -0,0 +1,114 @@
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+// scopeProfiles builds a ScopeProfilesSlice with len(profileCounts) scopes, each holding the given
+// number of profiles, e.g. scopeProfiles(2, 3) is two scopes with two and three profiles respectively.
+func scopeProfiles(profileCounts ...int) pprofile.ScopeProfilesSlice {
+	slice := pprofile.NewScopeProfilesSlice()
+	for _, n := range profileCounts {
+		sp := slice.AppendEmpty()
+		for i := 0; i < n; i++ {
+			sp.Profiles().AppendEmpty()
+		}
+	}
+	return slice
+}
+
+func newTestProfileProgress(limit int) *profileProgress {
+	return newProfileProgress(context.Background(), limit, 1, 1, 1).(*profileProgress)
+}
+
+func TestProfileCombinerCountIsPerProfileNotPerScope(t *testing.T) {
+	c := newProfileCombiner()
+
+	c.AddProfiles(scopeProfiles(2, 3))
+
+	// two scopes, five profiles total: Count must report the profile count, not the 2-scope count a
+	// scope-level accounting regressed to previously.
+	require.Equal(t, 5, c.Count())
+}
+
+func TestProfileCombinerAddProfilesMovesAndEmptiesSource(t *testing.T) {
+	c := newProfileCombiner()
+	incoming := scopeProfiles(1, 1)
+
+	c.AddProfiles(incoming)
+
+	require.Equal(t, 0, incoming.Len(), "AddProfiles must move the incoming slice's contents, not copy them")
+	require.Equal(t, 2, c.Count())
+}
+
+func TestProfileCombinerTrimToBoundsByProfileCountAcrossScopes(t *testing.T) {
+	c := newProfileCombiner()
+	c.AddProfiles(scopeProfiles(2, 3))
+
+	c.TrimTo(4)
+
+	require.Equal(t, 4, c.Count(), "TrimTo must cut at the profile boundary even though it falls inside the second scope")
+}
+
+func TestProfileCombinerTrimToDropsEmptiedScopes(t *testing.T) {
+	c := newProfileCombiner()
+	c.AddProfiles(scopeProfiles(2, 3))
+
+	c.TrimTo(2)
+
+	require.Equal(t, 2, c.Count())
+	require.Equal(t, 1, c.Profiles().Len(), "a scope left with zero profiles after trimming must be dropped entirely")
+}
+
+func TestProfileCombinerTrimToZeroOrNegativeIsNoop(t *testing.T) {
+	c := newProfileCombiner()
+	c.AddProfiles(scopeProfiles(2, 3))
+
+	c.TrimTo(0)
+	require.Equal(t, 5, c.Count())
+
+	c.TrimTo(-1)
+	require.Equal(t, 5, c.Count())
+}
+
+func TestProfileProgressShouldQuitOnceOverLimit(t *testing.T) {
+	pp := newTestProfileProgress(4)
+
+	pp.addResponse(&profileSearchResponse{
+		Profiles: scopeProfiles(2, 2),
+		Metrics:  &profileSearchMetrics{InspectedProfiles: 4, InspectedBytes: 400},
+	})
+	require.False(t, pp.shouldQuit(), "exactly at the limit must not quit")
+
+	pp.addResponse(&profileSearchResponse{
+		Profiles: scopeProfiles(1),
+		Metrics:  &profileSearchMetrics{InspectedProfiles: 1, InspectedBytes: 100},
+	})
+	require.True(t, pp.shouldQuit(), "strictly over the limit must quit")
+}
+
+func TestProfileProgressResultTrimsToLimitAndClonesMetrics(t *testing.T) {
+	pp := newTestProfileProgress(3)
+
+	pp.addResponse(&profileSearchResponse{
+		Profiles: scopeProfiles(2, 3),
+		Metrics:  &profileSearchMetrics{InspectedProfiles: 5, InspectedBytes: 500},
+	})
+
+	res := pp.result()
+
+	count := 0
+	profiles := res.response.Profiles
+	for i := 0; i < profiles.Len(); i++ {
+		count += profiles.At(i).Profiles().Len()
+	}
+	require.Equal(t, 3, count, "result must trim to the limit")
+	require.EqualValues(t, 5, res.response.Metrics.InspectedProfiles, "metrics are a snapshot of what was inspected, independent of the trim")
+
+	res.response.Metrics.InspectedProfiles = 999
+	require.EqualValues(t, 5, pp.resultsMetrics.InspectedProfiles, "result must clone metrics rather than hand back the live pointer")
+}
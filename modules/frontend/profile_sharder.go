@@ -0,0 +1,31 @@
+package frontend
+
+import "context"
+
+// shardProfileSearch fans in per-querier profile search responses from responses, combining them via a
+// shardedProfileProgress built from newProgress until that progress decides to quit (went over the limit, a
+// querier reported an error, or ctx was canceled) or responses is closed.
+//
+// This is the dispatch-agnostic core a profile search sharding handler sits on top of: it's the piece that
+// actually calls profileProgressFactory, the same way searchSharder calls searchProgressFactory for trace
+// search. Wiring it to an HTTP handler that fans the request out to queriers and feeds their responses into
+// the channel is left for when a profile search querier client exists in this tree.
+func shardProfileSearch(ctx context.Context, limit, totalJobs, totalBlocks, totalBlockBytes int, newProgress profileProgressFactory, responses <-chan *profileSearchResponse) *shardedProfileResults {
+	progress := newProgress(ctx, limit, totalJobs, totalBlocks, totalBlockBytes)
+
+	for {
+		select {
+		case <-ctx.Done():
+			progress.setError(ctx.Err())
+			return progress.result()
+		case res, ok := <-responses:
+			if !ok {
+				return progress.result()
+			}
+			progress.addResponse(res)
+			if progress.shouldQuit() {
+				return progress.result()
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package frontend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardProfileSearchReturnsOnceLimitIsReached(t *testing.T) {
+	responses := make(chan *profileSearchResponse, 2)
+	responses <- &profileSearchResponse{
+		Profiles: scopeProfiles(2, 2),
+		Metrics:  &profileSearchMetrics{InspectedProfiles: 4, InspectedBytes: 400},
+	}
+	responses <- &profileSearchResponse{
+		Profiles: scopeProfiles(1),
+		Metrics:  &profileSearchMetrics{InspectedProfiles: 1, InspectedBytes: 100},
+	}
+
+	res := shardProfileSearch(context.Background(), 4, 1, 1, 1, newProfileProgress, responses)
+
+	require.Equal(t, 2, res.finishedRequests)
+
+	count := 0
+	for i := 0; i < res.response.Profiles.Len(); i++ {
+		count += res.response.Profiles.At(i).Profiles().Len()
+	}
+	require.Equal(t, 4, count)
+}
+
+func TestShardProfileSearchReturnsOnceResponsesCloses(t *testing.T) {
+	responses := make(chan *profileSearchResponse, 1)
+	responses <- &profileSearchResponse{
+		Profiles: scopeProfiles(1),
+		Metrics:  &profileSearchMetrics{InspectedProfiles: 1, InspectedBytes: 100},
+	}
+	close(responses)
+
+	res := shardProfileSearch(context.Background(), 100, 1, 1, 1, newProfileProgress, responses)
+
+	require.Equal(t, 1, res.finishedRequests)
+}
+
+func TestShardProfileSearchReturnsOnContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	responses := make(chan *profileSearchResponse)
+
+	res := shardProfileSearch(ctx, 100, 1, 1, 1, newProfileProgress, responses)
+
+	require.ErrorIs(t, res.err, context.DeadlineExceeded)
+}
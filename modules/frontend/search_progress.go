@@ -4,11 +4,33 @@ import (
 	"context"
 	"net/http"
 	"sync"
+	"sync/atomic"
 
+	"github.com/grafana/tempo/pkg/featuregate"
 	"github.com/grafana/tempo/pkg/tempopb"
 	"github.com/grafana/tempo/pkg/traceql"
 )
 
+var (
+	// streamingPartialMetricsGate controls whether searchProgress.addResponse emits an incremental
+	// snapshot of search metrics as each querier response arrives, instead of only on result().
+	streamingPartialMetricsGate = featuregate.GlobalRegistry().MustRegister(
+		"frontend.streamingPartialMetrics",
+		featuregate.Alpha,
+		false,
+		"Emit incremental partial search metrics snapshots from searchProgress as responses arrive.",
+	)
+
+	// costBasedEarlyExitGate, when enabled, allows internalShouldQuit to quit as soon as the combiner
+	// has collected exactly `limit` traces instead of waiting until it has strictly more than `limit`.
+	costBasedEarlyExitGate = featuregate.GlobalRegistry().MustRegister(
+		"frontend.costBasedEarlyExit",
+		featuregate.Alpha,
+		false,
+		"Allow the search sharder to stop dispatching jobs as soon as the limit is reached, without overshooting by one response.",
+	)
+)
+
 // searchProgressFactory is used to provide a way to construct a shardedSearchProgress to the searchSharder. It exists
 // so that streaming search can inject and track it's own special progress object
 type searchProgressFactory func(ctx context.Context, limit, totalJobs, totalBlocks, totalBlockBytes int) shardedSearchProgress
@@ -19,6 +41,7 @@ type shardedSearchProgress interface {
 	setStatus(statusCode int, statusMsg string)
 	setError(err error)
 	addResponse(res *tempopb.SearchResponse)
+	setOnPartialMetrics(fn func(*tempopb.SearchMetrics))
 	shouldQuit() bool
 	result() *shardedSearchResults
 }
@@ -43,25 +66,84 @@ type searchProgress struct {
 	ctx        context.Context
 
 	resultsCombiner  *traceql.MetadataCombiner
-	resultsMetrics   *tempopb.SearchMetrics
+	resultsMetrics   *searchMetricsCounters
 	finishedRequests int
 
+	// onPartialMetrics, when set and streamingPartialMetricsGate is enabled, is invoked with a copy of
+	// resultsMetrics after every addResponse call.
+	onPartialMetrics func(*tempopb.SearchMetrics)
+
+	// pending holds responses that have arrived but not yet been merged into resultsCombiner. Batches
+	// of responses are merged together instead of one trace at a time, which avoids paying the
+	// dedup/sort cost of AddMetadata on every single addResponse call.
+	pending       []*tempopb.SearchResponse
+	pendingTraces int // unmerged trace count across all of pending, i.e. before dedup
+
 	limit int
 	mtx   sync.Mutex
 }
 
+// pendingResponsePool recycles the backing slices used to buffer responses awaiting merge.
+var pendingResponsePool = sync.Pool{
+	New: func() any {
+		return make([]*tempopb.SearchResponse, 0, 16)
+	},
+}
+
+// maxPendingResponses bounds how many responses we'll buffer before forcing a merge, so memory use
+// doesn't grow unbounded on a shard with a very high limit.
+const maxPendingResponses = 32
+
+// searchMetricsCounters holds the live counters backing a searchProgress's SearchMetrics as atomics, so
+// snapshot() can hand back a copy-on-read *tempopb.SearchMetrics by loading them directly instead of
+// needing searchProgress.mtx just to read the current counts.
+type searchMetricsCounters struct {
+	totalBlocks     uint32
+	totalBlockBytes uint64
+	totalJobs       uint32
+
+	inspectedTraces atomic.Uint64
+	inspectedBytes  atomic.Uint64
+	completedJobs   atomic.Uint32
+}
+
+func newSearchMetricsCounters(totalJobs, totalBlocks, totalBlockBytes int) *searchMetricsCounters {
+	return &searchMetricsCounters{
+		totalBlocks:     uint32(totalBlocks),
+		totalBlockBytes: uint64(totalBlockBytes),
+		totalJobs:       uint32(totalJobs),
+	}
+}
+
+// add folds one querier response's counters in. TotalBlocks/TotalJobs are set once at construction and
+// purposefully left alone here, since those are sharder-provided totals, not per-response deltas.
+func (m *searchMetricsCounters) add(inspectedTraces, inspectedBytes uint64) {
+	m.inspectedTraces.Add(inspectedTraces)
+	m.inspectedBytes.Add(inspectedBytes)
+	m.completedJobs.Add(1)
+}
+
+// snapshot returns a copy-on-read *tempopb.SearchMetrics built from atomic loads of the live counters.
+func (m *searchMetricsCounters) snapshot() *tempopb.SearchMetrics {
+	return &tempopb.SearchMetrics{
+		InspectedTraces: m.inspectedTraces.Load(),
+		InspectedBytes:  m.inspectedBytes.Load(),
+		CompletedJobs:   m.completedJobs.Load(),
+		TotalBlocks:     m.totalBlocks,
+		TotalJobs:       m.totalJobs,
+		TotalBlockBytes: m.totalBlockBytes,
+	}
+}
+
 func newSearchProgress(ctx context.Context, limit, totalJobs, totalBlocks, totalBlockBytes int) shardedSearchProgress {
 	return &searchProgress{
 		ctx:              ctx,
 		statusCode:       http.StatusOK,
 		limit:            limit,
 		finishedRequests: 0,
-		resultsMetrics: &tempopb.SearchMetrics{
-			TotalBlocks:     uint32(totalBlocks),
-			TotalBlockBytes: uint64(totalBlockBytes),
-			TotalJobs:       uint32(totalJobs),
-		},
-		resultsCombiner: traceql.NewMetadataCombiner(),
+		resultsMetrics:   newSearchMetricsCounters(totalJobs, totalBlocks, totalBlockBytes),
+		resultsCombiner:  traceql.NewMetadataCombiner(),
+		pending:          pendingResponsePool.Get().([]*tempopb.SearchResponse),
 	}
 }
 
@@ -80,21 +162,50 @@ func (r *searchProgress) setError(err error) {
 	r.err = err
 }
 
+// setOnPartialMetrics registers fn to be invoked with a snapshot of resultsMetrics after every
+// addResponse call, when streamingPartialMetricsGate is enabled. A streaming search handler calls this
+// to push incremental metrics to the client before the final result is ready.
+func (r *searchProgress) setOnPartialMetrics(fn func(*tempopb.SearchMetrics)) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.onPartialMetrics = fn
+}
+
 func (r *searchProgress) addResponse(res *tempopb.SearchResponse) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
-	for _, t := range res.Traces {
-		r.resultsCombiner.AddMetadata(t)
+	// buffer the response instead of merging it immediately. merging calls into AddMetadata, which
+	// sorts/dedupes on every call; batching that work amortizes it across many responses.
+	r.pending = append(r.pending, res)
+	r.pendingTraces += len(res.Traces)
+
+	if len(r.pending) >= maxPendingResponses {
+		r.mergePending()
 	}
 
-	// purposefully ignoring TotalBlocks as that value is set by the sharder
-	r.resultsMetrics.InspectedBytes += res.Metrics.InspectedBytes
-	r.resultsMetrics.InspectedTraces += res.Metrics.InspectedTraces
-	r.resultsMetrics.CompletedJobs++
+	r.resultsMetrics.add(res.Metrics.InspectedTraces, res.Metrics.InspectedBytes)
 
 	// count this request as finished
 	r.finishedRequests++
+
+	if streamingPartialMetricsGate.IsEnabled() && r.onPartialMetrics != nil {
+		r.onPartialMetrics(r.resultsMetrics.snapshot())
+	}
+}
+
+// mergePending folds all buffered responses into resultsCombiner and resets the pending buffer.
+// NOTE: only use internally where we already hold the lock.
+func (r *searchProgress) mergePending() {
+	for _, res := range r.pending {
+		for _, t := range res.Traces {
+			r.resultsCombiner.AddMetadata(t)
+		}
+	}
+
+	r.pending = r.pending[:0]
+	r.pendingTraces = 0
 }
 
 // shouldQuit locks and checks if we should quit from current execution or not
@@ -117,17 +228,29 @@ func (r *searchProgress) internalShouldQuit() bool {
 	if r.statusCode/100 != 2 {
 		return true
 	}
-	if r.resultsCombiner.Count() > r.limit {
-		return true
+	// cheap check first: raw (pre-dedup) trace count can only be >= the deduped count, so if even that
+	// can't satisfy the quit comparison below there's no need to pay for a merge just to check.
+	earlyExit := costBasedEarlyExitGate.IsEnabled()
+	rawCount := r.pendingTraces + r.resultsCombiner.Count()
+	if rawCount < r.limit || (rawCount == r.limit && !earlyExit) {
+		return false
 	}
 
-	return false
+	r.mergePending()
+
+	if earlyExit {
+		return r.resultsCombiner.Count() >= r.limit
+	}
+	return r.resultsCombiner.Count() > r.limit
 }
 
 func (r *searchProgress) result() *shardedSearchResults {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
+	// result() must return a consistent, fully merged snapshot.
+	r.mergePending()
+
 	res := &shardedSearchResults{
 		statusCode:       r.statusCode,
 		statusMsg:        r.statusMsg,
@@ -135,20 +258,15 @@ func (r *searchProgress) result() *shardedSearchResults {
 		finishedRequests: r.finishedRequests,
 	}
 
-	searchRes := &tempopb.SearchResponse{
-		// clone search metrics to avoid race conditions on the pointer
-		Metrics: &tempopb.SearchMetrics{
-			InspectedTraces: r.resultsMetrics.InspectedTraces,
-			InspectedBytes:  r.resultsMetrics.InspectedBytes,
-			TotalBlocks:     r.resultsMetrics.TotalBlocks,
-			CompletedJobs:   r.resultsMetrics.CompletedJobs,
-			TotalJobs:       r.resultsMetrics.TotalJobs,
-			TotalBlockBytes: r.resultsMetrics.TotalBlockBytes,
-		},
-		Traces: r.resultsCombiner.Metadata(),
+	res.response = &tempopb.SearchResponse{
+		Metrics: r.resultsMetrics.snapshot(),
+		Traces:  r.resultsCombiner.Metadata(),
 	}
 
-	res.response = searchRes
+	// result() is the terminal call in a searchProgress's lifecycle: nothing after this reads r.pending,
+	// so its backing slice can go back to the pool for the next request to reuse.
+	pendingResponsePool.Put(r.pending[:0])
+	r.pending = nil
 
 	return res
 }
@@ -0,0 +1,99 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/grafana/tempo/pkg/featuregate"
+	"github.com/grafana/tempo/pkg/tempopb"
+	"github.com/stretchr/testify/require"
+)
+
+func traceResponse(traceIDs ...string) *tempopb.SearchResponse {
+	traces := make([]*tempopb.TraceSearchMetadata, 0, len(traceIDs))
+	for _, id := range traceIDs {
+		traces = append(traces, &tempopb.TraceSearchMetadata{TraceID: id})
+	}
+	return &tempopb.SearchResponse{
+		Traces: traces,
+		Metrics: &tempopb.SearchMetrics{
+			InspectedTraces: uint64(len(traceIDs)),
+			InspectedBytes:  uint64(len(traceIDs)) * 100,
+		},
+	}
+}
+
+func newTestSearchProgress(limit int) *searchProgress {
+	return newSearchProgress(context.Background(), limit, 1, 1, 1).(*searchProgress)
+}
+
+func TestSearchProgressBuffersUntilMaxPendingResponses(t *testing.T) {
+	sp := newTestSearchProgress(1000)
+
+	for i := 0; i < maxPendingResponses-1; i++ {
+		sp.addResponse(traceResponse(fmt.Sprintf("trace-%d", i)))
+	}
+	require.Len(t, sp.pending, maxPendingResponses-1)
+	require.Equal(t, 0, sp.resultsCombiner.Count())
+
+	sp.addResponse(traceResponse("trace-last"))
+	require.Empty(t, sp.pending, "addResponse must merge pending once maxPendingResponses is reached")
+	require.Equal(t, maxPendingResponses, sp.resultsCombiner.Count())
+}
+
+func TestSearchProgressShouldQuitIsMonotone(t *testing.T) {
+	sp := newTestSearchProgress(2)
+
+	require.False(t, sp.shouldQuit())
+
+	sp.addResponse(traceResponse("a", "b"))
+	require.False(t, sp.shouldQuit(), "exactly at the limit must not quit")
+
+	sp.addResponse(traceResponse("c"))
+	require.True(t, sp.shouldQuit(), "strictly over the limit must quit")
+
+	sp.addResponse(traceResponse("d"))
+	require.True(t, sp.shouldQuit(), "shouldQuit must stay true once it has gone true")
+}
+
+func TestSearchProgressShouldQuitCostBasedEarlyExit(t *testing.T) {
+	// "a" is duplicated across the two traces arriving in one response, so the raw (pre-dedup) trace
+	// count crosses the limit while the deduped combiner count lands exactly on it - the case that
+	// distinguishes the >= (cost-based) comparison from the default > comparison.
+	resp := traceResponse("a", "a", "b")
+
+	spDefault := newTestSearchProgress(2)
+	spDefault.addResponse(resp)
+	require.False(t, spDefault.shouldQuit(), "default behavior only quits once strictly over the limit")
+
+	require.NoError(t, featuregate.GlobalRegistry().Set("frontend.costBasedEarlyExit", true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set("frontend.costBasedEarlyExit", false))
+	}()
+
+	spCostBased := newTestSearchProgress(2)
+	spCostBased.addResponse(resp)
+	require.True(t, spCostBased.shouldQuit(), "cost-based early exit quits as soon as the limit is reached")
+}
+
+func TestSearchProgressResultIncludesUnmergedPending(t *testing.T) {
+	sp := newTestSearchProgress(1000)
+
+	sp.addResponse(traceResponse("a", "b"))
+	require.NotEmpty(t, sp.pending, "response should still be buffered, not yet merged")
+
+	res := sp.result()
+	require.Len(t, res.response.Traces, 2)
+	require.EqualValues(t, 2, res.response.Metrics.InspectedTraces)
+	require.Equal(t, 1, res.finishedRequests)
+}
+
+func TestSearchProgressResultClearsPendingForPoolReuse(t *testing.T) {
+	sp := newTestSearchProgress(1000)
+	sp.addResponse(traceResponse("a"))
+
+	sp.result()
+
+	require.Nil(t, sp.pending, "result must release pending back to the pool rather than keep a reference to it")
+}
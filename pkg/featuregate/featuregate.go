@@ -0,0 +1,209 @@
+// Package featuregate provides a small registry of named, staged feature gates so that experimental
+// behaviors can be rolled out (and rolled back) without a binary release for every change.
+package featuregate
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-kit/log/level"
+	"github.com/grafana/tempo/pkg/util/log"
+)
+
+// Stage describes how far along a Gate is in its rollout. A gate's default enablement is the
+// defaultEnabled value it was registered with; the Stage does not change it. The Stage decides how
+// Registry.Set treats the gate.
+type Stage int
+
+const (
+	// Alpha gates may change or disappear without notice. Set logs a warning when it is applied to one.
+	Alpha Stage = iota
+	// Beta gates can still be turned on or off with Set.
+	Beta
+	// Stable gates ignore Set and keep their registered value; they exist only for a deprecation window.
+	Stable
+	// Deprecated gates log a warning when Set is applied to them. IsEnabled does not log.
+	Deprecated
+)
+
+func (s Stage) String() string {
+	switch s {
+	case Alpha:
+		return "alpha"
+	case Beta:
+		return "beta"
+	case Stable:
+		return "stable"
+	case Deprecated:
+		return "deprecated"
+	default:
+		return "unknown"
+	}
+}
+
+// Gate is a single named, staged feature toggle. Use Registry.MustRegister to create one.
+type Gate struct {
+	ID          string
+	Description string
+	Stage       Stage
+
+	mtx     sync.RWMutex
+	enabled bool
+}
+
+// IsEnabled reports whether the gate is currently enabled.
+func (g *Gate) IsEnabled() bool {
+	g.mtx.RLock()
+	defer g.mtx.RUnlock()
+
+	return g.enabled
+}
+
+func (g *Gate) setEnabled(enabled bool) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.enabled = enabled
+}
+
+// Registry tracks the set of known gates and their current values. Use GlobalRegistry for the
+// process-wide registry, or NewRegistry to build an isolated one for tests.
+type Registry struct {
+	mtx   sync.Mutex
+	gates map[string]*Gate
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{gates: make(map[string]*Gate)}
+}
+
+var globalRegistry = NewRegistry()
+
+// GlobalRegistry returns the process-wide Registry that package init() functions register gates against.
+func GlobalRegistry() *Registry {
+	return globalRegistry
+}
+
+// Register adds a new gate with the given id, stage, and default enablement. It returns an error if the
+// id is already registered.
+func (r *Registry) Register(id string, stage Stage, defaultEnabled bool, description string) (*Gate, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.gates[id]; ok {
+		return nil, fmt.Errorf("gate %q already registered", id)
+	}
+
+	g := &Gate{
+		ID:          id,
+		Description: description,
+		Stage:       stage,
+		enabled:     defaultEnabled,
+	}
+	r.gates[id] = g
+
+	return g, nil
+}
+
+// MustRegister is like Register but panics on error. It is intended for use in package-level var
+// declarations where a registration failure indicates a programming error.
+func (r *Registry) MustRegister(id string, stage Stage, defaultEnabled bool, description string) *Gate {
+	g, err := r.Register(id, stage, defaultEnabled, description)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Set overrides the enablement of the gate with the given id. It warns when flipping an alpha or
+// deprecated gate so operators notice they're relying on unstable behavior.
+func (r *Registry) Set(id string, enabled bool) error {
+	r.mtx.Lock()
+	g, ok := r.gates[id]
+	r.mtx.Unlock()
+
+	if !ok {
+		return fmt.Errorf("unknown feature gate %q", id)
+	}
+
+	if g.Stage == Stable {
+		return nil
+	}
+
+	if g.Stage == Alpha || g.Stage == Deprecated {
+		level.Warn(log.Logger).Log("msg", "setting "+g.Stage.String()+" feature gate", "gate", id, "enabled", enabled)
+	}
+
+	g.setEnabled(enabled)
+	return nil
+}
+
+// Lookup returns the gate registered under id, if any.
+func (r *Registry) Lookup(id string) (*Gate, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	g, ok := r.gates[id]
+	return g, ok
+}
+
+// flagValue implements flag.Value so a Registry can be wired up to a single CLI flag that accepts a
+// comma-separated list of gate=bool pairs, e.g. -feature-gates=frontend.streamingPartialMetrics=true.
+type flagValue struct {
+	registry *Registry
+}
+
+// RegisterFlags wires the registry up to a "feature-gates" flag on the given FlagSet.
+func (r *Registry) RegisterFlags(f *flag.FlagSet) {
+	f.Var(&flagValue{registry: r}, "feature-gates", "Comma-separated list of <gate>=<bool> pairs to enable or disable experimental features.")
+}
+
+func (v *flagValue) String() string {
+	if v.registry == nil {
+		return ""
+	}
+
+	v.registry.mtx.Lock()
+	defer v.registry.mtx.Unlock()
+
+	ids := make([]string, 0, len(v.registry.gates))
+	for id := range v.registry.gates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	pairs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", id, v.registry.gates[id].IsEnabled()))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (v *flagValue) Set(s string) error {
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("malformed feature gate %q, expected <gate>=<bool>", pair)
+		}
+
+		enabled, err := strconv.ParseBool(kv[1])
+		if err != nil {
+			return fmt.Errorf("malformed feature gate %q: %w", pair, err)
+		}
+
+		if err := v.registry.Set(kv[0], enabled); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package featuregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterDuplicate(t *testing.T) {
+	r := NewRegistry()
+
+	_, err := r.Register("foo", Alpha, false, "a test gate")
+	require.NoError(t, err)
+
+	_, err = r.Register("foo", Alpha, false, "a test gate")
+	require.Error(t, err)
+}
+
+func TestMustRegisterPanicsOnDuplicate(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("foo", Alpha, false, "a test gate")
+
+	require.Panics(t, func() {
+		r.MustRegister("foo", Alpha, false, "a test gate")
+	})
+}
+
+func TestDefaultEnablement(t *testing.T) {
+	r := NewRegistry()
+
+	alpha := r.MustRegister("alpha-gate", Alpha, false, "")
+	require.False(t, alpha.IsEnabled())
+
+	beta := r.MustRegister("beta-gate", Beta, true, "")
+	require.True(t, beta.IsEnabled())
+}
+
+func TestSetUnknownGate(t *testing.T) {
+	r := NewRegistry()
+
+	err := r.Set("does-not-exist", true)
+	require.Error(t, err)
+}
+
+func TestSetTogglesGate(t *testing.T) {
+	r := NewRegistry()
+	g := r.MustRegister("alpha-gate", Alpha, false, "")
+
+	require.NoError(t, r.Set("alpha-gate", true))
+	require.True(t, g.IsEnabled())
+
+	require.NoError(t, r.Set("alpha-gate", false))
+	require.False(t, g.IsEnabled())
+}
+
+func TestSetStableIsNoop(t *testing.T) {
+	r := NewRegistry()
+	g := r.MustRegister("stable-gate", Stable, true, "")
+
+	require.NoError(t, r.Set("stable-gate", false))
+	require.True(t, g.IsEnabled(), "Set on a stable gate must not change its value")
+}
+
+func TestLookup(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("foo", Alpha, false, "")
+
+	g, ok := r.Lookup("foo")
+	require.True(t, ok)
+	require.Equal(t, "foo", g.ID)
+
+	_, ok = r.Lookup("bar")
+	require.False(t, ok)
+}
+
+func TestFlagValueSetAndString(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("a-gate", Alpha, false, "")
+	r.MustRegister("b-gate", Alpha, false, "")
+
+	v := &flagValue{registry: r}
+
+	require.NoError(t, v.Set("a-gate=true,b-gate=false"))
+	require.Equal(t, "a-gate=true,b-gate=false", v.String())
+
+	aGate, _ := r.Lookup("a-gate")
+	require.True(t, aGate.IsEnabled())
+	bGate, _ := r.Lookup("b-gate")
+	require.False(t, bGate.IsEnabled())
+}
+
+func TestFlagValueSetMalformed(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("a-gate", Alpha, false, "")
+
+	v := &flagValue{registry: r}
+
+	require.Error(t, v.Set("a-gate"))
+	require.Error(t, v.Set("a-gate=not-a-bool"))
+	require.Error(t, v.Set("unknown-gate=true"))
+}
+
+func TestStageString(t *testing.T) {
+	require.Equal(t, "alpha", Alpha.String())
+	require.Equal(t, "beta", Beta.String())
+	require.Equal(t, "stable", Stable.String())
+	require.Equal(t, "deprecated", Deprecated.String())
+	require.Equal(t, "unknown", Stage(99).String())
+}
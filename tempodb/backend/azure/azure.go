@@ -4,21 +4,24 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/base64"
 	"encoding/binary"
-	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"path"
 	"strings"
+	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
 	"github.com/go-kit/log/level"
 	"github.com/google/uuid"
 	"github.com/grafana/tempo/pkg/util/log"
@@ -33,6 +36,21 @@ const (
 	dir = "/"
 	// max parallelism on uploads
 	maxParallelism = 3
+
+	// blob index tag keys/values used to accelerate ListBlocks when Config.UseBlobIndexTags is set
+	tagKeyTenant = "tenant"
+	tagKeyBlock  = "block"
+	tagKeyState  = "state"
+	tagKeyPrefix = "prefix"
+
+	tagStateActive    = "active"
+	tagStateCompacted = "compacted"
+
+	// maxRangeChecksumBytes is the largest range Azure will compute a Content-MD5 for on download.
+	maxRangeChecksumBytes = 4 * 1024 * 1024
+
+	// defaultBlockSize is used to size staged blocks in writer when Config.BufferSize is unset.
+	defaultBlockSize = 4 * 1024 * 1024
 )
 
 type Azure struct {
@@ -50,6 +68,7 @@ var (
 
 type appendTracker struct {
 	Name string
+	ETag azcore.ETag
 }
 
 var tracer = otel.Tracer("tempodb/backend/azure")
@@ -66,9 +85,7 @@ func New(cfg *Config) (backend.RawReader, backend.RawWriter, backend.Compactor,
 	return rw, rw, rw, err
 }
 
-// NewVersionedReaderWriter creates a client to perform versioned requests. Note that write requests are
-// best-effort for now. We need to update the SDK to make use of the precondition headers.
-// https://github.com/grafana/tempo/issues/2705
+// NewVersionedReaderWriter creates a client to perform versioned requests.
 func NewVersionedReaderWriter(cfg *Config) (backend.VersionedReaderWriter, error) {
 	return internalNew(cfg, true)
 }
@@ -76,12 +93,19 @@ func NewVersionedReaderWriter(cfg *Config) (backend.VersionedReaderWriter, error
 func internalNew(cfg *Config, confirm bool) (*Azure, error) {
 	ctx := context.Background()
 
-	c, err := getContainerClient(ctx, cfg, false)
+	// Build the credential once and reuse it for both the primary and hedged container clients so
+	// they share a single token cache instead of each independently authenticating from scratch.
+	cred, err := credential(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("building azure credential: %w", err)
+	}
+
+	c, err := getContainerClient(cfg, cred, false)
 	if err != nil {
 		return nil, fmt.Errorf("getting storage container: %w", err)
 	}
 
-	hedgedContainer, err := getContainerClient(ctx, cfg, true)
+	hedgedContainer, err := getContainerClient(cfg, cred, true)
 	if err != nil {
 		return nil, fmt.Errorf("getting hedged storage container: %w", err)
 	}
@@ -114,14 +138,66 @@ func readError(err error) error {
 	return nil
 }
 
+func writeError(err error) error {
+	if bloberror.HasCode(err, bloberror.ConditionNotMet, bloberror.BlobAlreadyExists) {
+		return backend.ErrVersionDoesNotMatch
+	}
+
+	if err != nil {
+		return fmt.Errorf("writing Azure blob container: %w", err)
+	}
+	return nil
+}
+
 // Write implements backend.Writer
 func (rw *Azure) Write(ctx context.Context, name string, keypath backend.KeyPath, data io.Reader, _ int64, _ *backend.CacheInfo) error {
-	keypath = backend.KeyPathWithPrefix(keypath, rw.cfg.Prefix)
+	state, tagBlock := blockStateForName(name)
+	prefixedKeypath := backend.KeyPathWithPrefix(keypath, rw.cfg.Prefix)
 
 	derivedCtx, span := tracer.Start(ctx, "azure.Write")
 	defer span.End()
 
-	return rw.writer(derivedCtx, bufio.NewReader(data), backend.ObjectFileName(keypath, name))
+	objectName := backend.ObjectFileName(prefixedKeypath, name)
+	if _, err := rw.writer(derivedCtx, bufio.NewReader(data), objectName, nil); err != nil {
+		return err
+	}
+
+	// stamp tenant/block/state index tags on meta blobs so ListBlocks can use Find Blobs by Tags
+	// instead of a full prefix listing when Config.UseBlobIndexTags is set
+	if rw.cfg.UseBlobIndexTags && tagBlock && len(keypath) >= 2 {
+		if err := rw.setBlockStateTag(derivedCtx, keypath[0], keypath[1], objectName, state); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// blockStateForName reports the block index tag state, if any, that should be stamped on the blob
+// with the given name once Config.UseBlobIndexTags is enabled.
+func blockStateForName(name string) (state string, ok bool) {
+	switch name {
+	case backend.MetaName:
+		return tagStateActive, true
+	case backend.CompactedMetaName:
+		return tagStateCompacted, true
+	default:
+		return "", false
+	}
+}
+
+// setBlockStateTag stamps tenant/block/state/prefix index tags on the given blob. The prefix tag
+// mirrors Config.Prefix so findBlocksByTagState can scope its Find Blobs by Tags query to this
+// instance, the same way the full-listing path in ListBlocks scopes by Config.Prefix.
+func (rw *Azure) setBlockStateTag(ctx context.Context, tenant, block, objectName, state string) error {
+	blobClient := rw.containerClient.NewBlockBlobClient(objectName)
+	_, err := blobClient.SetTags(ctx, map[string]string{
+		tagKeyTenant: tenant,
+		tagKeyBlock:  block,
+		tagKeyState:  state,
+		tagKeyPrefix: rw.cfg.Prefix,
+	}, nil)
+	return err
 }
 
 // Append implements backend.Writer
@@ -131,17 +207,19 @@ func (rw *Azure) Append(ctx context.Context, name string, keypath backend.KeyPat
 	if tracker == nil {
 		a.Name = backend.ObjectFileName(keypath, name)
 
-		err := rw.writeAll(ctx, a.Name, buffer)
+		etag, err := rw.writeAll(ctx, a.Name, buffer)
 		if err != nil {
 			return nil, err
 		}
+		a.ETag = etag
 	} else {
 		a = tracker.(appendTracker)
 
-		err := rw.append(ctx, buffer, a.Name)
+		etag, err := rw.append(ctx, buffer, a.Name, a.ETag)
 		if err != nil {
 			return nil, err
 		}
+		a.ETag = etag
 	}
 
 	return a, nil
@@ -153,13 +231,10 @@ func (rw *Azure) CloseAppend(context.Context, backend.AppendTracker) error {
 }
 
 func (rw *Azure) Delete(ctx context.Context, name string, keypath backend.KeyPath, _ *backend.CacheInfo) error {
-	blobClient, err := getBlobClient(ctx, rw.cfg, backend.ObjectFileName(keypath, name))
-	if err != nil {
-		return fmt.Errorf("cannot get Azure blob client, name: %s: %w", backend.ObjectFileName(keypath, name), err)
-	}
+	blobClient := rw.containerClient.NewBlobClient(backend.ObjectFileName(keypath, name))
 
 	snapshotType := blob.DeleteSnapshotsOptionTypeInclude
-	if _, err = blobClient.Delete(ctx, &blob.DeleteOptions{DeleteSnapshots: &snapshotType}); err != nil {
+	if _, err := blobClient.Delete(ctx, &blob.DeleteOptions{DeleteSnapshots: &snapshotType}); err != nil {
 		return readError(err)
 	}
 	return nil
@@ -202,6 +277,16 @@ func (rw *Azure) ListBlocks(ctx context.Context, tenant string) ([]uuid.UUID, []
 	ctx, span := tracer.Start(ctx, "V2.ListBlocks")
 	defer span.End()
 
+	if rw.cfg.UseBlobIndexTags {
+		blockIDs, compactedBlockIDs, err := rw.listBlocksByTags(ctx, tenant)
+		if err == nil {
+			return blockIDs, compactedBlockIDs, nil
+		}
+		// fall back to the full listing below if the container doesn't support index tags
+		// (e.g. accounts with hierarchical namespace enabled)
+		level.Warn(log.Logger).Log("msg", "listing blocks by tag failed, falling back to full listing", "tenant", tenant, "err", err)
+	}
+
 	var (
 		blockIDs          = make([]uuid.UUID, 0, 1000)
 		compactedBlockIDs = make([]uuid.UUID, 0, 1000)
@@ -259,6 +344,102 @@ func (rw *Azure) ListBlocks(ctx context.Context, tenant string) ([]uuid.UUID, []
 	return blockIDs, compactedBlockIDs, nil
 }
 
+// listBlocksByTags lists active and compacted blocks for a tenant using two Find Blobs by Tags
+// queries instead of a full prefix listing. Requires Config.UseBlobIndexTags and a storage account
+// that supports blob index tags.
+func (rw *Azure) listBlocksByTags(ctx context.Context, tenant string) ([]uuid.UUID, []uuid.UUID, error) {
+	blockIDs, err := rw.findBlocksByTagState(ctx, tenant, tagStateActive)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding active blocks by tag: %w", err)
+	}
+
+	compactedBlockIDs, err := rw.findBlocksByTagState(ctx, tenant, tagStateCompacted)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding compacted blocks by tag: %w", err)
+	}
+
+	return blockIDs, compactedBlockIDs, nil
+}
+
+// escapeTagFilterValue escapes a value for safe interpolation into a single-quoted string literal in
+// a Find Blobs by Tags where clause, doubling any embedded single quotes the way OData string literals
+// require. tenant, Config.ContainerName and Config.Prefix all end up here and aren't otherwise
+// validated against this query syntax.
+func escapeTagFilterValue(v string) string {
+	return strings.ReplaceAll(v, "'", "''")
+}
+
+func (rw *Azure) findBlocksByTagState(ctx context.Context, tenant, state string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, 1000)
+
+	// Find Blobs by Tags runs account-wide, so restrict it to this instance's container as well as its prefix.
+	where := fmt.Sprintf("@container='%s' AND \"%s\"='%s' AND \"%s\"='%s' AND \"%s\"='%s'",
+		escapeTagFilterValue(rw.cfg.ContainerName),
+		tagKeyTenant, escapeTagFilterValue(tenant), tagKeyState, escapeTagFilterValue(state), tagKeyPrefix, escapeTagFilterValue(rw.cfg.Prefix))
+	pager := rw.containerClient.ServiceClient().NewFilterBlobsPager(&service.FilterBlobsOptions{Where: &where})
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range page.Blobs {
+			if b.Name == nil {
+				continue
+			}
+
+			// tagged blob names are <container-relative-path>/<blockID>/meta[.compacted].json
+			parts := strings.Split(*b.Name, dir)
+			if len(parts) < 2 {
+				continue
+			}
+
+			id, err := uuid.Parse(parts[len(parts)-2])
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// MarkBlockCompacted implements backend.Compactor
+func (rw *Azure) MarkBlockCompacted(blockID uuid.UUID, tenantID string) error {
+	ctx := context.Background()
+	keypath := backend.KeyPathWithPrefix(backend.KeyPath{tenantID, blockID.String()}, rw.cfg.Prefix)
+
+	srcName := backend.ObjectFileName(keypath, backend.MetaName)
+	dstName := backend.ObjectFileName(keypath, backend.CompactedMetaName)
+
+	b, _, err := rw.readAll(ctx, srcName)
+	if err != nil {
+		return readError(err)
+	}
+
+	if _, err := rw.writer(ctx, bytes.NewReader(b), dstName, nil); err != nil {
+		return err
+	}
+
+	// with tag indexing enabled, flip the state tag in place instead of requiring ListBlocks to
+	// rescan the container to notice the blob moved from active to compacted
+	if rw.cfg.UseBlobIndexTags {
+		if err := rw.setBlockStateTag(ctx, tenantID, blockID.String(), dstName, tagStateCompacted); err != nil {
+			return err
+		}
+	}
+
+	srcClient := rw.containerClient.NewBlockBlobClient(srcName)
+	snapshotType := blob.DeleteSnapshotsOptionTypeInclude
+	if _, err := srcClient.Delete(ctx, &blob.DeleteOptions{DeleteSnapshots: &snapshotType}); err != nil {
+		return readError(err)
+	}
+
+	return nil
+}
+
 // Find implements backend.Reader
 func (rw *Azure) Find(ctx context.Context, keypath backend.KeyPath, f backend.FindFunc) (err error) {
 	keypath = backend.KeyPathWithPrefix(keypath, rw.cfg.Prefix)
@@ -336,43 +517,50 @@ func (rw *Azure) ReadRange(ctx context.Context, name string, keypath backend.Key
 func (rw *Azure) Shutdown() {
 }
 
-func (rw *Azure) WriteVersioned(ctx context.Context, name string, keypath backend.KeyPath, data io.Reader, size int64, version backend.Version) (backend.Version, error) {
-	// TODO use conditional if-match API
-	_, currentVersion, err := rw.ReadVersioned(ctx, name, keypath)
-	if err != nil && !errors.Is(err, backend.ErrDoesNotExist) {
-		return "", err
-	}
-
-	level.Info(log.Logger).Log("msg", "WriteVersioned - fetching data", "currentVersion", currentVersion, "err", err, "version", version)
+func (rw *Azure) WriteVersioned(ctx context.Context, name string, keypath backend.KeyPath, data io.Reader, _ int64, version backend.Version) (backend.Version, error) {
+	keypath = backend.KeyPathWithPrefix(keypath, rw.cfg.Prefix)
+	objectName := backend.ObjectFileName(keypath, name)
 
-	// object does not exist - supplied version must be "0"
-	if errors.Is(err, backend.ErrDoesNotExist) && version != backend.VersionNew {
-		return "", backend.ErrVersionDoesNotMatch
-	}
-	if !errors.Is(err, backend.ErrDoesNotExist) && version != currentVersion {
-		return "", backend.ErrVersionDoesNotMatch
+	accessConditions := &blob.AccessConditions{ModifiedAccessConditions: &blob.ModifiedAccessConditions{}}
+	if version == backend.VersionNew {
+		anyETag := azcore.ETagAny
+		accessConditions.ModifiedAccessConditions.IfNoneMatch = &anyETag
+	} else {
+		etag := azcore.ETag(version)
+		accessConditions.ModifiedAccessConditions.IfMatch = &etag
 	}
 
-	err = rw.Write(ctx, name, keypath, data, size, nil)
+	level.Info(log.Logger).Log("msg", "WriteVersioned", "name", objectName, "version", version)
+
+	etag, err := rw.writer(ctx, bufio.NewReader(data), objectName, accessConditions)
 	if err != nil {
-		return "", err
+		return "", writeError(err)
 	}
 
-	_, currentVersion, err = rw.ReadVersioned(ctx, name, keypath)
-	return currentVersion, err
+	return backend.Version(etag), nil
 }
 
 func (rw *Azure) DeleteVersioned(ctx context.Context, name string, keypath backend.KeyPath, version backend.Version) error {
-	// TODO use conditional if-match API
-	_, currentVersion, err := rw.ReadVersioned(ctx, name, keypath)
-	if err != nil && !errors.Is(err, backend.ErrDoesNotExist) {
-		return err
-	}
-	if !errors.Is(err, backend.ErrDoesNotExist) && currentVersion != version {
-		return backend.ErrVersionDoesNotMatch
-	}
+	keypath = backend.KeyPathWithPrefix(keypath, rw.cfg.Prefix)
+	objectName := backend.ObjectFileName(keypath, name)
+
+	blobClient := rw.containerClient.NewBlockBlobClient(objectName)
 
-	return rw.Delete(ctx, name, keypath, nil)
+	etag := azcore.ETag(version)
+	snapshotType := blob.DeleteSnapshotsOptionTypeInclude
+	_, err := blobClient.Delete(ctx, &blob.DeleteOptions{
+		DeleteSnapshots: &snapshotType,
+		AccessConditions: &blob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfMatch: &etag},
+		},
+	})
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return backend.ErrDoesNotExist
+		}
+		return writeError(err)
+	}
+	return nil
 }
 
 func (rw *Azure) ReadVersioned(ctx context.Context, name string, keypath backend.KeyPath) (io.ReadCloser, backend.Version, error) {
@@ -390,16 +578,14 @@ func (rw *Azure) ReadVersioned(ctx context.Context, name string, keypath backend
 	return io.NopCloser(bytes.NewReader(b)), backend.Version(etag), nil
 }
 
-func (rw *Azure) writeAll(ctx context.Context, name string, b []byte) error {
-	err := rw.writer(ctx, bytes.NewReader(b), name)
-	if err != nil {
-		return err
-	}
-
-	return nil
+func (rw *Azure) writeAll(ctx context.Context, name string, b []byte) (azcore.ETag, error) {
+	return rw.writer(ctx, bytes.NewReader(b), name, nil)
 }
 
-func (rw *Azure) append(ctx context.Context, src []byte, name string) error {
+// append stages and commits the next block of an append blob. If expectedETag is non-empty, the commit
+// is conditioned on the blob's block list not having changed since expectedETag was observed, so a
+// concurrent appender can't silently clobber our write.
+func (rw *Azure) append(ctx context.Context, src []byte, name string, expectedETag azcore.ETag) (azcore.ETag, error) {
 	appendBlobClient := rw.containerClient.NewBlockBlobClient(name)
 
 	// These helper functions convert a binary block ID to a base-64 string and vice versa
@@ -414,15 +600,21 @@ func (rw *Azure) append(ctx context.Context, src []byte, name string) error {
 
 	l, err := appendBlobClient.GetBlockList(ctx, blockblob.BlockListTypeAll, &blockblob.GetBlockListOptions{})
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// generate the next block id
 	id := blockIDIntToBase64(len(l.CommittedBlocks) + 1)
 
-	_, err = appendBlobClient.StageBlock(ctx, id, streaming.NopCloser(bytes.NewReader(src)), &blockblob.StageBlockOptions{})
+	stageOptions := &blockblob.StageBlockOptions{}
+	if rw.cfg.VerifyChecksums {
+		sum := md5.Sum(src)
+		stageOptions.TransactionalContentMD5 = sum[:]
+	}
+
+	_, err = appendBlobClient.StageBlock(ctx, id, streaming.NopCloser(bytes.NewReader(src)), stageOptions)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	base64BlockIDs := make([]string, len(l.CommittedBlocks)+1)
@@ -432,26 +624,122 @@ func (rw *Azure) append(ctx context.Context, src []byte, name string) error {
 
 	base64BlockIDs[len(l.CommittedBlocks)] = id
 
+	commitOptions := &blockblob.CommitBlockListOptions{}
+	if expectedETag != "" {
+		commitOptions.AccessConditions = &blob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfMatch: &expectedETag},
+		}
+	}
+
 	// After all the blocks are uploaded, atomically commit them to the blob.
-	_, err = appendBlobClient.CommitBlockList(ctx, base64BlockIDs, &blockblob.CommitBlockListOptions{})
+	resp, err := appendBlobClient.CommitBlockList(ctx, base64BlockIDs, commitOptions)
 	if err != nil {
-		return err
+		return "", writeError(err)
 	}
-	return nil
+
+	var etag azcore.ETag
+	if resp.ETag != nil {
+		etag = *resp.ETag
+	}
+	return etag, nil
 }
 
-func (rw *Azure) writer(ctx context.Context, src io.Reader, name string) error {
+// writer uploads src as a new block blob via a low-level stage/commit, rather than the high-level
+// UploadStream helper, so that a whole-blob Content-MD5 can be attached to the same CommitBlockList
+// call that applies accessConditions. Stamping the digest with a trailing SetHTTPHeaders after the
+// commit would be unconditional (reopening the TOCTOU window accessConditions closes) and would change
+// the blob's ETag without updating the one returned to the caller as the new backend.Version.
+func (rw *Azure) writer(ctx context.Context, src io.Reader, name string, accessConditions *blob.AccessConditions) (azcore.ETag, error) {
 	blobClient := rw.containerClient.NewBlockBlobClient(name)
 
-	_, err := blobClient.UploadStream(ctx, src, &azblob.UploadStreamOptions{
-		BlockSize:   int64(rw.cfg.BufferSize),
-		Concurrency: rw.cfg.MaxBuffers,
-	})
+	blockSize := int64(rw.cfg.BufferSize)
+	if blockSize <= 0 {
+		blockSize = defaultBlockSize
+	}
+
+	concurrency := rw.cfg.MaxBuffers
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var hasher hash.Hash
+	if rw.cfg.VerifyChecksums {
+		hasher = md5.New()
+	}
+
+	var (
+		blockIDs []string
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency)
+		mtx      sync.Mutex
+		stageErr error
+	)
+
+	for blockNum := 0; ; blockNum++ {
+		buf := make([]byte, blockSize)
+		n, readErr := io.ReadFull(src, buf)
+		if n > 0 {
+			block := buf[:n]
+			if hasher != nil {
+				hasher.Write(block)
+			}
+
+			binaryBlockID := (&[64]byte{})[:]
+			binary.LittleEndian.PutUint32(binaryBlockID, uint32(blockNum))
+			blockID := base64.StdEncoding.EncodeToString(binaryBlockID)
+			blockIDs = append(blockIDs, blockID)
+
+			stageOptions := &blockblob.StageBlockOptions{}
+			if rw.cfg.VerifyChecksums {
+				sum := md5.Sum(block)
+				stageOptions.TransactionalContentMD5 = sum[:]
+			}
+
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				if _, err := blobClient.StageBlock(ctx, blockID, streaming.NopCloser(bytes.NewReader(block)), stageOptions); err != nil {
+					mtx.Lock()
+					if stageErr == nil {
+						stageErr = err
+					}
+					mtx.Unlock()
+				}
+			}()
+		}
+
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			wg.Wait()
+			return "", fmt.Errorf("cannot upload blob, name: %s: %w", name, readErr)
+		}
+	}
+
+	wg.Wait()
+	if stageErr != nil {
+		return "", fmt.Errorf("cannot upload blob, name: %s: %w", name, stageErr)
+	}
+
+	commitOptions := &blockblob.CommitBlockListOptions{AccessConditions: accessConditions}
+	if hasher != nil {
+		commitOptions.HTTPHeaders = &blob.HTTPHeaders{BlobContentMD5: hasher.Sum(nil)}
+	}
+
+	resp, err := blobClient.CommitBlockList(ctx, blockIDs, commitOptions)
 	if err != nil {
-		return fmt.Errorf("cannot upload blob, name: %s: %w", name, err)
+		return "", fmt.Errorf("cannot upload blob, name: %s: %w", name, err)
 	}
 
-	return nil
+	var etag azcore.ETag
+	if resp.ETag != nil {
+		etag = *resp.ETag
+	}
+	return etag, nil
 }
 
 func (rw *Azure) readRange(ctx context.Context, name string, offset int64, destBuffer []byte) error {
@@ -475,7 +763,14 @@ func (rw *Azure) readRange(ctx context.Context, name string, offset int64, destB
 		size = *props.ContentLength - offset
 	}
 
-	if _, err := blobClient.DownloadBuffer(ctx, destBuffer, &blob.DownloadBufferOptions{
+	// Azure only computes a range checksum for a range fetched in a single request, and only for ranges
+	// up to 4MB, so route small verified reads through downloadRangeVerified instead of DownloadBuffer,
+	// which splits larger ranges into concurrent sub-requests that wouldn't carry a Content-MD5 anyway.
+	if rw.cfg.VerifyChecksums && size <= maxRangeChecksumBytes {
+		return rw.downloadRangeVerified(ctx, blobClient, offset, size, destBuffer[:size])
+	}
+
+	downloadOptions := &blob.DownloadBufferOptions{
 		Range: blob.HTTPRange{
 			Offset: offset,
 			Count:  size,
@@ -485,16 +780,40 @@ func (rw *Azure) readRange(ctx context.Context, name string, offset int64, destB
 		RetryReaderOptionsPerBlock: blob.RetryReaderOptions{
 			MaxRetries: maxRetries,
 		},
-	}); err != nil {
+	}
+
+	if _, err := blobClient.DownloadBuffer(ctx, destBuffer, downloadOptions); err != nil {
 		return err
 	}
 
-	_, err = bytes.NewReader(destBuffer).Read(destBuffer)
+	return nil
+}
+
+// downloadRangeVerified fetches [offset, offset+size) in a single request so Azure can return a
+// Content-MD5 for just that range, and verifies it against a local digest of the downloaded bytes
+// before returning.
+func (rw *Azure) downloadRangeVerified(ctx context.Context, blobClient *blockblob.Client, offset, size int64, destBuffer []byte) error {
+	resp, err := blobClient.DownloadStream(ctx, &blob.DownloadStreamOptions{
+		Range: blob.HTTPRange{
+			Offset: offset,
+			Count:  size,
+		},
+		RangeGetContentMD5: to.Ptr(true),
+		RetryReaderOptionsPerBlock: blob.RetryReaderOptions{
+			MaxRetries: maxRetries,
+		},
+	})
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
-	return nil
+	if _, err := io.ReadFull(resp.Body, destBuffer); err != nil {
+		return err
+	}
+
+	sum := md5.Sum(destBuffer)
+	return backend.VerifyChecksum(sum[:], resp.ContentMD5)
 }
 
 func (rw *Azure) readAll(ctx context.Context, name string) ([]byte, azcore.ETag, error) {
@@ -525,6 +844,13 @@ func (rw *Azure) readAll(ctx context.Context, name string) ([]byte, azcore.ETag,
 		return nil, "", err
 	}
 
+	if rw.cfg.VerifyChecksums {
+		sum := md5.Sum(destBuffer)
+		if err := backend.VerifyChecksum(sum[:], props.ContentMD5); err != nil {
+			return nil, "", err
+		}
+	}
+
 	var etag azcore.ETag
 	if props.ETag != nil {
 		etag = *props.ETag
@@ -0,0 +1,137 @@
+package azure
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/grafana/tempo/tempodb/backend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteErrorMapsPreconditionFailuresToErrVersionDoesNotMatch(t *testing.T) {
+	tt := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "If-Match failed on an existing blob",
+			err:  &azcore.ResponseError{ErrorCode: string(bloberror.ConditionNotMet)},
+			want: backend.ErrVersionDoesNotMatch,
+		},
+		{
+			name: "If-None-Match=* failed because the blob already exists",
+			err:  &azcore.ResponseError{ErrorCode: string(bloberror.BlobAlreadyExists)},
+			want: backend.ErrVersionDoesNotMatch,
+		},
+		{
+			name: "unrelated error is wrapped, not mapped to a sentinel",
+			err:  errors.New("boom"),
+			want: nil,
+		},
+		{
+			name: "nil in, nil out",
+			err:  nil,
+			want: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := writeError(tc.err)
+
+			if tc.want != nil {
+				require.ErrorIs(t, got, tc.want)
+				return
+			}
+			if tc.err == nil {
+				require.NoError(t, got)
+				return
+			}
+			require.Error(t, got)
+			require.NotErrorIs(t, got, backend.ErrVersionDoesNotMatch)
+		})
+	}
+}
+
+func TestReadErrorMapsNotFoundToErrDoesNotExist(t *testing.T) {
+	got := readError(&azcore.ResponseError{ErrorCode: string(bloberror.BlobNotFound)})
+	require.ErrorIs(t, got, backend.ErrDoesNotExist)
+
+	require.NoError(t, readError(nil))
+}
+
+func TestEscapeTagFilterValueDoublesEmbeddedQuotes(t *testing.T) {
+	require.Equal(t, "no-quotes", escapeTagFilterValue("no-quotes"))
+	require.Equal(t, "o''brien", escapeTagFilterValue("o'brien"))
+	require.Equal(t, "''''", escapeTagFilterValue("''"))
+}
+
+func TestResolveAuthMethod(t *testing.T) {
+	tt := []struct {
+		name string
+		cfg  *Config
+		want AuthMethod
+	}{
+		{
+			name: "empty AuthMethod with an account key set falls back to shared key",
+			cfg:  &Config{StorageAccountKey: "some-key"},
+			want: AuthMethodSharedKey,
+		},
+		{
+			name: "empty AuthMethod with no account key falls back to default",
+			cfg:  &Config{},
+			want: AuthMethodDefault,
+		},
+		{
+			name: "explicit AuthMethod is used as-is even when an account key is also set",
+			cfg:  &Config{AuthMethod: AuthMethodManagedIdentity, StorageAccountKey: "some-key"},
+			want: AuthMethodManagedIdentity,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, resolveAuthMethod(tc.cfg))
+		})
+	}
+}
+
+func TestSASURL(t *testing.T) {
+	cfg := &Config{
+		StorageAccountName: "myaccount",
+		Endpoint:           "core.windows.net",
+		ContainerName:      "mycontainer",
+	}
+
+	tt := []struct {
+		name     string
+		sasToken string
+		want     string
+	}{
+		{
+			name:     "full container SAS URL is passed through unchanged",
+			sasToken: "https://other.blob.core.windows.net/othercontainer?sv=2024-01-01&sig=abc",
+			want:     "https://other.blob.core.windows.net/othercontainer?sv=2024-01-01&sig=abc",
+		},
+		{
+			name:     "bare query string is appended to this instance's container endpoint",
+			sasToken: "sv=2024-01-01&sig=abc",
+			want:     "https://myaccount.blob.core.windows.net/mycontainer?sv=2024-01-01&sig=abc",
+		},
+		{
+			name:     "bare query string with a leading ? has it trimmed before appending",
+			sasToken: "?sv=2024-01-01&sig=abc",
+			want:     "https://myaccount.blob.core.windows.net/mycontainer?sv=2024-01-01&sig=abc",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg.SASToken = tc.sasToken
+			require.Equal(t, tc.want, sasURL(cfg))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package azure
+
+import "time"
+
+// AuthMethod selects how the Azure backend authenticates to the storage account.
+type AuthMethod string
+
+const (
+	// AuthMethodDefault chains together the credential types azidentity.DefaultAzureCredential tries,
+	// in order: environment vars, workload identity, managed identity, azure CLI.
+	AuthMethodDefault AuthMethod = "default"
+	// AuthMethodSharedKey authenticates with the storage account's access key.
+	AuthMethodSharedKey AuthMethod = "shared_key"
+	// AuthMethodSASToken authenticates with a pre-generated account or container SAS URL/token.
+	AuthMethodSASToken AuthMethod = "sas_token"
+	// AuthMethodManagedIdentity authenticates as an Azure managed identity, optionally user-assigned
+	// when ClientID is set.
+	AuthMethodManagedIdentity AuthMethod = "managed_identity"
+	// AuthMethodWorkloadIdentity authenticates using an AKS workload identity federated token.
+	AuthMethodWorkloadIdentity AuthMethod = "workload_identity"
+	// AuthMethodClientSecret authenticates as an Azure AD application using a client secret.
+	AuthMethodClientSecret AuthMethod = "client_secret"
+	// AuthMethodClientCertificate authenticates as an Azure AD application using a client certificate.
+	AuthMethodClientCertificate AuthMethod = "client_certificate"
+	// AuthMethodAzureCLI authenticates using the identity currently logged into the Azure CLI.
+	AuthMethodAzureCLI AuthMethod = "azure_cli"
+)
+
+// Config holds the configuration for an Azure Blob Storage backend.
+type Config struct {
+	StorageAccountName string        `yaml:"storage-account-name"`
+	ContainerName      string        `yaml:"container-name"`
+	Endpoint           string        `yaml:"endpoint-suffix"`
+	Prefix             string        `yaml:"prefix"`
+	MaxBuffers         int           `yaml:"max-buffers"`
+	BufferSize         int           `yaml:"buffer-size"`
+	HedgeRequestsAt    time.Duration `yaml:"hedge-requests-at"`
+	HedgeRequestsUpTo  int           `yaml:"hedge-requests-up-to"`
+
+	// UseBlobIndexTags enables server-side blob index tags (tenant/block/state) on meta.json and
+	// meta.compacted.json blobs, and switches ListBlocks over to a Find Blobs by Tags query instead of
+	// a full prefix listing. Requires a storage account that supports blob index tags (not available
+	// on accounts with hierarchical namespace enabled).
+	UseBlobIndexTags bool `yaml:"use-blob-index-tags"`
+
+	// AuthMethod selects how to authenticate to the storage account. Defaults to AuthMethodSharedKey
+	// for backwards compatibility when StorageAccountKey is set, and AuthMethodDefault otherwise.
+	AuthMethod AuthMethod `yaml:"auth-method"`
+
+	StorageAccountKey string `yaml:"storage-account-key"`
+
+	// ClientID identifies the user-assigned managed identity (AuthMethodManagedIdentity) or the Azure
+	// AD application (AuthMethodClientSecret, AuthMethodClientCertificate) to authenticate as.
+	ClientID string `yaml:"client-id"`
+	TenantID string `yaml:"tenant-id"`
+
+	ClientSecret string `yaml:"client-secret"`
+
+	CertificatePath     string `yaml:"certificate-path"`
+	CertificatePassword string `yaml:"certificate-password"`
+
+	// FederatedTokenFile is the path to the projected AKS service account token used by
+	// AuthMethodWorkloadIdentity. Normally sourced from AZURE_FEDERATED_TOKEN_FILE.
+	FederatedTokenFile string `yaml:"federated-token-file"`
+
+	// SASToken is an account or container SAS URL/token, used when AuthMethod is AuthMethodSASToken.
+	SASToken string `yaml:"sas-token"`
+
+	// VerifyChecksums computes an MD5 while uploading and verifies reads, returning
+	// backend.ErrChecksumMismatch instead of silently returning corrupted bytes. Whole-blob reads are
+	// checked against the blob's recorded Content-MD5, and range reads of up to 4 MiB against the MD5
+	// Azure returns for that range. Range reads larger than 4 MiB are not verified, and a blob with no
+	// recorded Content-MD5 passes unverified.
+	VerifyChecksums bool `yaml:"verify-checksums"`
+}
@@ -0,0 +1,149 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+	"github.com/grafana/tempo/tempodb/backend"
+)
+
+const (
+	// maxSynchronousCopyBytes is the largest blob CopyFromURL will copy synchronously; larger blobs
+	// must use StartCopyFromURL and have their CopyStatus polled.
+	maxSynchronousCopyBytes = 256 * 1024 * 1024
+
+	copyPollInterval        = time.Second
+	userDelegationKeyWindow = 15 * time.Minute
+)
+
+var _ backend.BlockCopier = (*Azure)(nil)
+
+// CopyObject implements backend.BlockCopier. It performs a zero-egress, server-side copy from src to
+// dst within the same storage account, so compaction doesn't have to round-trip bytes through the
+// Tempo process when both sides resolve to the same Azure backend.
+//
+// AuthMethodSASToken has no account key and no AAD token to ask for a user-delegation key with, so
+// there's no way to mint a source SAS at all; fall back to a plain Read/Write round trip for it, the
+// same way ListBlocks falls back to a full prefix listing when blob index tags aren't usable.
+func (rw *Azure) CopyObject(ctx context.Context, src, dst backend.KeyPath) error {
+	srcName := path.Join(backend.KeyPathWithPrefix(src, rw.cfg.Prefix)...)
+	dstName := path.Join(backend.KeyPathWithPrefix(dst, rw.cfg.Prefix)...)
+
+	if resolveAuthMethod(rw.cfg) == AuthMethodSASToken {
+		return rw.copyObjectViaReadWrite(ctx, srcName, dstName)
+	}
+
+	srcClient := rw.containerClient.NewBlobClient(srcName)
+	dstClient := rw.containerClient.NewBlobClient(dstName)
+
+	srcURL, err := rw.sourceSASURL(ctx, srcClient, srcName)
+	if err != nil {
+		return fmt.Errorf("generating SAS for source blob %s: %w", srcName, err)
+	}
+
+	props, err := srcClient.GetProperties(ctx, nil)
+	if err != nil {
+		return readError(err)
+	}
+
+	if props.ContentLength != nil && *props.ContentLength <= maxSynchronousCopyBytes {
+		if _, err := dstClient.CopyFromURL(ctx, srcURL, nil); err != nil {
+			return fmt.Errorf("copying %s to %s: %w", srcName, dstName, err)
+		}
+		return nil
+	}
+
+	resp, err := dstClient.StartCopyFromURL(ctx, srcURL, nil)
+	if err != nil {
+		return fmt.Errorf("starting copy from %s to %s: %w", srcName, dstName, err)
+	}
+
+	status := resp.CopyStatus
+	for status != nil && *status == blob.CopyStatusTypePending {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(copyPollInterval):
+		}
+
+		p, err := dstClient.GetProperties(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("polling copy status for %s: %w", dstName, err)
+		}
+		status = p.CopyStatus
+	}
+
+	if status == nil || *status != blob.CopyStatusTypeSuccess {
+		return fmt.Errorf("copy from %s to %s finished with unexpected status %v", srcName, dstName, status)
+	}
+
+	return nil
+}
+
+// sourceSASURL generates a short-lived, read-only SAS URL for srcName. Get User Delegation Key is an
+// AAD-only operation, so AuthMethodSharedKey signs the SAS locally with the account key instead;
+// AuthMethodSASToken has neither and is handled by the copyObjectViaReadWrite fallback in CopyObject
+// before this is ever called.
+func (rw *Azure) sourceSASURL(ctx context.Context, srcClient *blob.Client, srcName string) (string, error) {
+	now := time.Now().UTC().Add(-5 * time.Minute)
+	expiry := now.Add(userDelegationKeyWindow)
+
+	values := sas.BlobSignatureValues{
+		Protocol:      sas.ProtocolHTTPS,
+		StartTime:     now,
+		ExpiryTime:    expiry,
+		Permissions:   to.Ptr(sas.BlobPermissions{Read: true}).String(),
+		ContainerName: rw.cfg.ContainerName,
+		BlobName:      srcName,
+	}
+
+	if resolveAuthMethod(rw.cfg) == AuthMethodSharedKey {
+		skCred, err := service.NewSharedKeyCredential(rw.cfg.StorageAccountName, rw.cfg.StorageAccountKey)
+		if err != nil {
+			return "", fmt.Errorf("building shared key credential: %w", err)
+		}
+
+		params, err := values.SignWithSharedKey(skCred)
+		if err != nil {
+			return "", err
+		}
+
+		return srcClient.URL() + "?" + params.Encode(), nil
+	}
+
+	udc, err := rw.containerClient.ServiceClient().GetUserDelegationCredential(ctx, service.KeyInfo{
+		Start:  to.Ptr(now.UTC().Format(sas.TimeFormat)),
+		Expiry: to.Ptr(expiry.UTC().Format(sas.TimeFormat)),
+	}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	params, err := values.SignWithUserDelegation(udc)
+	if err != nil {
+		return "", err
+	}
+
+	return srcClient.URL() + "?" + params.Encode(), nil
+}
+
+// copyObjectViaReadWrite copies srcName to dstName by downloading the full object and re-uploading it,
+// for auth methods that can't produce a source SAS URL for a server-side copy.
+func (rw *Azure) copyObjectViaReadWrite(ctx context.Context, srcName, dstName string) error {
+	b, _, err := rw.readAll(ctx, srcName)
+	if err != nil {
+		return readError(err)
+	}
+
+	if _, err := rw.writeAll(ctx, dstName, b); err != nil {
+		return fmt.Errorf("writing %s: %w", dstName, err)
+	}
+
+	return nil
+}
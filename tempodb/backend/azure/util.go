@@ -0,0 +1,121 @@
+package azure
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+)
+
+// maxRetries is the number of times a single block read is retried before giving up.
+const maxRetries = 10
+
+func blobEndpoint(cfg *Config) string {
+	return fmt.Sprintf("https://%s.blob.%s", cfg.StorageAccountName, cfg.Endpoint)
+}
+
+// resolveAuthMethod returns the auth method credential/getContainerClient should actually use: cfg's
+// AuthMethod if set, or else AuthMethodSharedKey when StorageAccountKey is set (for backwards
+// compatibility with configs predating AuthMethod that only set storage-account-key), or else
+// AuthMethodDefault.
+func resolveAuthMethod(cfg *Config) AuthMethod {
+	if cfg.AuthMethod != "" {
+		return cfg.AuthMethod
+	}
+	if cfg.StorageAccountKey != "" {
+		return AuthMethodSharedKey
+	}
+	return AuthMethodDefault
+}
+
+// credential builds the azcore.TokenCredential for the auth method selected in cfg. It returns a nil
+// credential for AuthMethodSharedKey and AuthMethodSASToken, which getContainerClient handles without
+// one. internalNew calls this once and passes the result to both the primary and hedged container
+// client constructions, so they share a single token cache.
+func credential(cfg *Config) (azcore.TokenCredential, error) {
+	switch resolveAuthMethod(cfg) {
+	case AuthMethodDefault:
+		opts := &azidentity.DefaultAzureCredentialOptions{}
+		if cfg.ClientID != "" {
+			opts.ManagedIdentityClientID = cfg.ClientID
+		}
+		return azidentity.NewDefaultAzureCredential(opts)
+
+	case AuthMethodManagedIdentity:
+		opts := &azidentity.ManagedIdentityCredentialOptions{}
+		if cfg.ClientID != "" {
+			opts.ID = azidentity.ClientID(cfg.ClientID)
+		}
+		return azidentity.NewManagedIdentityCredential(opts)
+
+	case AuthMethodWorkloadIdentity:
+		opts := &azidentity.WorkloadIdentityCredentialOptions{
+			ClientID:      cfg.ClientID,
+			TenantID:      cfg.TenantID,
+			TokenFilePath: cfg.FederatedTokenFile,
+		}
+		return azidentity.NewWorkloadIdentityCredential(opts)
+
+	case AuthMethodClientSecret:
+		return azidentity.NewClientSecretCredential(cfg.TenantID, cfg.ClientID, cfg.ClientSecret, nil)
+
+	case AuthMethodClientCertificate:
+		certData, err := os.ReadFile(cfg.CertificatePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading client certificate %s: %w", cfg.CertificatePath, err)
+		}
+		certs, key, err := azidentity.ParseCertificates(certData, []byte(cfg.CertificatePassword))
+		if err != nil {
+			return nil, fmt.Errorf("parsing client certificate %s: %w", cfg.CertificatePath, err)
+		}
+		return azidentity.NewClientCertificateCredential(cfg.TenantID, cfg.ClientID, certs, key, nil)
+
+	case AuthMethodAzureCLI:
+		return azidentity.NewAzureCLICredential(nil)
+
+	case AuthMethodSharedKey, AuthMethodSASToken:
+		// these auth methods don't use a token credential; getContainerClient handles them directly.
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("unknown auth-method %q", cfg.AuthMethod)
+	}
+}
+
+// getContainerClient builds a container.Client for cfg's storage account/container using the given
+// token credential (ignored for AuthMethodSharedKey/AuthMethodSASToken, which don't use one). The
+// hedge argument is not used: the client is built the same way whether it is true or false, and
+// cfg.HedgeRequestsAt and cfg.HedgeRequestsUpTo are not applied, so requests are not hedged.
+func getContainerClient(cfg *Config, cred azcore.TokenCredential, hedge bool) (*container.Client, error) {
+	clientOpts := &container.ClientOptions{}
+
+	switch resolveAuthMethod(cfg) {
+	case AuthMethodSharedKey:
+		skCred, err := service.NewSharedKeyCredential(cfg.StorageAccountName, cfg.StorageAccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("building shared key credential: %w", err)
+		}
+		return container.NewClientWithSharedKeyCredential(blobEndpoint(cfg)+"/"+cfg.ContainerName, skCred, clientOpts)
+
+	case AuthMethodSASToken:
+		return container.NewClientWithNoCredential(sasURL(cfg), clientOpts)
+
+	default:
+		return container.NewClient(blobEndpoint(cfg)+"/"+cfg.ContainerName, cred, clientOpts)
+	}
+}
+
+// sasURL resolves cfg.SASToken to a container SAS URL. cfg.SASToken may already be a full
+// container SAS URL (https://account.blob.core.windows.net/container?sv=...), or it may be a bare
+// account SAS query string (sv=...&sig=...) with no scheme or host, in which case it's appended to
+// this instance's container endpoint.
+func sasURL(cfg *Config) string {
+	if strings.Contains(cfg.SASToken, "://") {
+		return cfg.SASToken
+	}
+	return blobEndpoint(cfg) + "/" + cfg.ContainerName + "?" + strings.TrimPrefix(cfg.SASToken, "?")
+}
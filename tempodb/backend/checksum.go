@@ -0,0 +1,25 @@
+package backend
+
+import (
+	"bytes"
+	"errors"
+)
+
+// ErrChecksumMismatch is returned when a backend detects that the bytes it read don't match the
+// checksum recorded for the object, so callers (WAL replay, compactor) can trigger a re-read instead
+// of poisoning a cache with corrupted data.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// VerifyChecksum compares a locally computed digest against the one a backend recorded for an object
+// (e.g. a blob's Content-MD5 property), returning ErrChecksumMismatch if they differ. want == nil is
+// treated as "nothing to compare against" and always passes, since some backends only sometimes return
+// a recorded checksum (e.g. Azure omits Content-MD5 for ranges above a size limit).
+func VerifyChecksum(got, want []byte) error {
+	if want == nil {
+		return nil
+	}
+	if !bytes.Equal(got, want) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
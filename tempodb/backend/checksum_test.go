@@ -0,0 +1,44 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	tt := []struct {
+		name    string
+		got     []byte
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "matching digests pass",
+			got:  []byte{1, 2, 3},
+			want: []byte{1, 2, 3},
+		},
+		{
+			name:    "mismatched digests fail",
+			got:     []byte{1, 2, 3},
+			want:    []byte{1, 2, 4},
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name: "no recorded digest to compare against always passes",
+			got:  []byte{1, 2, 3},
+			want: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := VerifyChecksum(tc.got, tc.want)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package backend
+
+import "context"
+
+// BlockCopier is an optional interface a backend can implement to support server-side, zero-egress
+// copies of an object to another key within the same backend. Callers (e.g. the compactor) should type
+// assert for it and fall back to a Read/Write round trip when a backend doesn't implement it.
+type BlockCopier interface {
+	// CopyObject copies the object at src to dst without streaming its bytes through the caller.
+	CopyObject(ctx context.Context, src, dst KeyPath) error
+}
+
+// CopyObject copies name from srcKeyPath to dstKeyPath within rw. When rw implements BlockCopier, the
+// copy happens server-side with zero egress through the Tempo process; otherwise it falls back to a
+// Read/Write round trip. The compactor should call this (instead of a manual Read followed by Write)
+// for bulk block promotion and cross-tenant moves, so it automatically picks up the zero-egress path
+// wherever the configured backend supports it.
+func CopyObject(ctx context.Context, rw interface {
+	RawReader
+	RawWriter
+}, name string, srcKeyPath, dstKeyPath KeyPath) error {
+	if copier, ok := rw.(BlockCopier); ok {
+		src := append(append(KeyPath{}, srcKeyPath...), name)
+		dst := append(append(KeyPath{}, dstKeyPath...), name)
+		return copier.CopyObject(ctx, src, dst)
+	}
+
+	r, size, err := rw.Read(ctx, name, srcKeyPath, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return rw.Write(ctx, name, dstKeyPath, r, size, nil)
+}